@@ -0,0 +1,160 @@
+// Package bench runs the ga engine across a matrix of configurations and
+// collects per-run statistics, so operator and population choices can be
+// tuned against numbers instead of reading stdout scroll by.
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/xyproto/nqueens/ga"
+)
+
+// Config describes one configuration to benchmark: NewGA builds a fresh
+// *ga.GA wired with whatever board size, population size and operators
+// this configuration wants to test, using the rng it's given.
+type Config struct {
+	Name  string
+	NewGA func(rng *rand.Rand) *ga.GA
+}
+
+// Result is the outcome of running one Config once, with a fixed seed.
+type Result struct {
+	Config                   string        `json:"config"`
+	Seed                     int64         `json:"seed"`
+	GenerationsToSolve       int           `json:"generations_to_solve"` // -1 if not reached
+	BestFitnessPerGeneration []float64     `json:"best_fitness_per_generation"`
+	MeanFitness              float64       `json:"mean_fitness"`
+	StdDevFitness            float64       `json:"stddev_fitness"`
+	Elapsed                  time.Duration `json:"elapsed_ns"`
+}
+
+// Matrix is a set of configurations to benchmark, each repeated MinIters
+// times with a distinct seed so results aren't an artifact of one lucky
+// (or unlucky) RNG stream.
+type Matrix struct {
+	Configs  []Config
+	MinIters uint
+	BaseSeed int64
+}
+
+// Run executes every Config in the matrix MinIters times and returns one
+// Result per run.
+func (m Matrix) Run() []Result {
+	results := make([]Result, 0, len(m.Configs)*int(m.MinIters))
+	for _, cfg := range m.Configs {
+		for i := uint(0); i < m.MinIters; i++ {
+			results = append(results, RunOnce(cfg, m.BaseSeed+int64(i)))
+		}
+	}
+	return results
+}
+
+// RunOnce runs a single Config to completion (either it reaches fitness
+// 1.0, or it exhausts its GA's MaxGenerations) and records statistics
+// along the way.
+func RunOnce(cfg Config, seed int64) Result {
+	rng := rand.New(rand.NewSource(seed))
+	engine := cfg.NewGA(rng)
+	pop := engine.InitPopulation()
+
+	result := Result{
+		Config:             cfg.Name,
+		Seed:               seed,
+		GenerationsToSolve: -1,
+	}
+
+	start := time.Now()
+	for generation := uint(0); generation < engine.MaxGenerations; generation++ {
+		var bestFitness float64
+		pop, _, bestFitness = engine.StepGeneration(pop)
+
+		mean, stddev := fitnessStats(pop)
+		result.BestFitnessPerGeneration = append(result.BestFitnessPerGeneration, bestFitness)
+		result.MeanFitness = mean
+		result.StdDevFitness = stddev
+
+		if bestFitness == 1.0 {
+			result.GenerationsToSolve = int(generation)
+			break
+		}
+	}
+	result.Elapsed = time.Since(start)
+
+	return result
+}
+
+func fitnessStats(pop []ga.Genome) (mean, stddev float64) {
+	n := len(pop)
+	fitnesses := make([]float64, n)
+	var sum float64
+	for i, ind := range pop {
+		fitnesses[i] = ind.Fitness()
+		sum += fitnesses[i]
+	}
+	mean = sum / float64(n)
+	var variance float64
+	for _, f := range fitnesses {
+		d := f - mean
+		variance += d * d
+	}
+	variance /= float64(n)
+	return mean, math.Sqrt(variance)
+}
+
+// WriteCSV writes one row per Result, with the per-generation best
+// fitness history flattened into a single semicolon-separated column.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	header := []string{"config", "seed", "generations_to_solve", "mean_fitness", "stddev_fitness", "elapsed_ms", "best_fitness_per_generation"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range results {
+		history := ""
+		for i, f := range r.BestFitnessPerGeneration {
+			if i > 0 {
+				history += ";"
+			}
+			history += strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		row := []string{
+			r.Config,
+			strconv.FormatInt(r.Seed, 10),
+			strconv.Itoa(r.GenerationsToSolve),
+			strconv.FormatFloat(r.MeanFitness, 'f', -1, 64),
+			strconv.FormatFloat(r.StdDevFitness, 'f', -1, 64),
+			strconv.FormatInt(r.Elapsed.Milliseconds(), 10),
+			history,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the full result set, history included, as a single
+// JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// Summarize prints a one-line human-readable summary of each result to
+// w, mainly useful when iterating on a config interactively.
+func Summarize(w io.Writer, results []Result) {
+	for _, r := range results {
+		fmt.Fprintf(w, "%s seed=%d generations=%d mean=%.3f stddev=%.3f elapsed=%s\n",
+			r.Config, r.Seed, r.GenerationsToSolve, r.MeanFitness, r.StdDevFitness, r.Elapsed)
+	}
+}
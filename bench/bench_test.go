@@ -0,0 +1,71 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/xyproto/nqueens/ga"
+	"github.com/xyproto/nqueens/nqueens"
+)
+
+func permutationConfig(n uint) Config {
+	return Config{
+		Name: "permutation",
+		NewGA: func(rng *rand.Rand) *ga.GA {
+			return ga.New(
+				200,
+				500,
+				nqueens.PermRandomInitializer{NumQueens: n},
+				ga.TournamentSelector{K: 2},
+				nqueens.OrderCrossover{},
+				nqueens.PermSwapMutator{},
+				rng,
+			)
+		},
+	}
+}
+
+// BenchmarkSolve exercises a full RunOnce per iteration, so `go test
+// -bench Solve ./bench` catches regressions in the operator mix showing
+// up as slower convergence, not just slower code.
+func BenchmarkSolve(b *testing.B) {
+	cfg := permutationConfig(8)
+	for i := 0; i < b.N; i++ {
+		RunOnce(cfg, int64(i))
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	results := []Result{RunOnce(permutationConfig(8), 1)}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, results); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var decoded []Result
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON produced invalid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Config != results[0].Config {
+		t.Errorf("decoded = %+v, want a single result matching %+v", decoded, results[0])
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	results := []Result{RunOnce(permutationConfig(8), 1)}
+
+	var buf bytes.Buffer
+	Summarize(&buf, results)
+
+	out := buf.String()
+	if !strings.Contains(out, results[0].Config) {
+		t.Errorf("Summarize output %q does not mention config %q", out, results[0].Config)
+	}
+	if !strings.Contains(out, "seed=1") {
+		t.Errorf("Summarize output %q does not mention the seed", out)
+	}
+}
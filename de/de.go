@@ -0,0 +1,184 @@
+// Package de implements Differential Evolution, a real-valued
+// alternative to the ga package's genetic algorithm. It is
+// problem-agnostic: callers supply a FitnessFunc that maps a vector in
+// [0,1]^Dim to a score in [0,1], the same range ga.Genome.Fitness uses,
+// so the two solvers are directly comparable on the same problem.
+package de
+
+import "math/rand"
+
+// MutationStrategy selects how the donor vector is built for each
+// target in a generation.
+type MutationStrategy int
+
+const (
+	// Rand1Bin: donor = x_r1 + F*(x_r2 - x_r3), for three distinct
+	// random individuals r1, r2, r3.
+	Rand1Bin MutationStrategy = iota
+	// Best1Bin: donor = x_best + F*(x_r1 - x_r2).
+	Best1Bin
+	// CurrentToBest1Bin: donor = x_i + F*(x_best - x_i) + F*(x_r1 - x_r2).
+	CurrentToBest1Bin
+)
+
+// FitnessFunc scores a candidate vector; higher is better, and 1.0 means
+// the vector solves the problem.
+type FitnessFunc func(vector []float64) float64
+
+// DE holds the configuration for one Differential Evolution run. The
+// zero value is not usable; construct one with New.
+type DE struct {
+	Dim         uint
+	NP          uint // population size, must be at least 4 (see New)
+	Generations uint
+	F           float64 // differential weight, typically 0.5-0.9
+	CR          float64 // crossover probability, typically 0.7-0.9
+	Strategy    MutationStrategy
+	Fitness     FitnessFunc
+	Rng         *rand.Rand
+}
+
+// minNP is the smallest population size distinctIndices can serve: each
+// trial needs three donor indices distinct from each other and from the
+// target, so a population of 3 or fewer would make it spin forever.
+const minNP = 4
+
+// New returns a DE ready to Run. It panics if np is below minNP, since
+// distinctIndices would otherwise loop forever trying to find three
+// donor indices distinct from the target in a population too small to
+// hold them.
+func New(dim, np, generations uint, f, cr float64, strategy MutationStrategy, fitness FitnessFunc, rng *rand.Rand) *DE {
+	if np < minNP {
+		panic("de: NP must be at least 4")
+	}
+	return &DE{
+		Dim:         dim,
+		NP:          np,
+		Generations: generations,
+		F:           f,
+		CR:          cr,
+		Strategy:    strategy,
+		Fitness:     fitness,
+		Rng:         rng,
+	}
+}
+
+// Run drives the DE loop until a vector reaches fitness 1.0 or
+// Generations is exhausted, and returns the best vector found, its
+// fitness, and the number of generations that ran.
+func (d *DE) Run() ([]float64, float64, uint) {
+	pop := make([][]float64, d.NP)
+	fit := make([]float64, d.NP)
+	for i := range pop {
+		pop[i] = d.randomVector()
+		fit[i] = d.Fitness(pop[i])
+	}
+	bestIndex := argmax(fit)
+
+	var generation uint
+	for generation = 0; generation < d.Generations; generation++ {
+		if fit[bestIndex] == 1.0 {
+			break
+		}
+		for i := range pop {
+			trial := d.trialVector(pop, i, bestIndex)
+			trialFitness := d.Fitness(trial)
+			if trialFitness >= fit[i] {
+				pop[i] = trial
+				fit[i] = trialFitness
+				if trialFitness > fit[bestIndex] {
+					bestIndex = i
+				}
+			}
+		}
+	}
+
+	return pop[bestIndex], fit[bestIndex], generation
+}
+
+func (d *DE) randomVector() []float64 {
+	v := make([]float64, d.Dim)
+	for i := range v {
+		v[i] = d.Rng.Float64()
+	}
+	return v
+}
+
+// trialVector builds the donor for target index i according to the
+// configured MutationStrategy, then applies binomial crossover against
+// the target to produce the trial vector.
+func (d *DE) trialVector(pop [][]float64, i, bestIndex int) []float64 {
+	r1, r2, r3 := d.distinctIndices(i)
+
+	donor := make([]float64, d.Dim)
+	switch d.Strategy {
+	case Best1Bin:
+		for j := range donor {
+			donor[j] = pop[bestIndex][j] + d.F*(pop[r1][j]-pop[r2][j])
+		}
+	case CurrentToBest1Bin:
+		for j := range donor {
+			donor[j] = pop[i][j] + d.F*(pop[bestIndex][j]-pop[i][j]) + d.F*(pop[r1][j]-pop[r2][j])
+		}
+	default: // Rand1Bin
+		for j := range donor {
+			donor[j] = pop[r1][j] + d.F*(pop[r2][j]-pop[r3][j])
+		}
+	}
+
+	trial := make([]float64, d.Dim)
+	jrand := d.Rng.Intn(int(d.Dim))
+	for j := 0; j < int(d.Dim); j++ {
+		if d.Rng.Float64() < d.CR || j == jrand {
+			trial[j] = clamp01(donor[j])
+		} else {
+			trial[j] = pop[i][j]
+		}
+	}
+	return trial
+}
+
+// distinctIndices picks three indices into the population, all distinct
+// from each other and from exclude.
+func (d *DE) distinctIndices(exclude int) (int, int, int) {
+	pick := func(avoid ...int) int {
+		for {
+			candidate := d.Rng.Intn(int(d.NP))
+			clashes := false
+			for _, a := range avoid {
+				if candidate == a {
+					clashes = true
+					break
+				}
+			}
+			if !clashes {
+				return candidate
+			}
+		}
+	}
+	r1 := pick(exclude)
+	r2 := pick(exclude, r1)
+	r3 := pick(exclude, r1, r2)
+	return r1, r2, r3
+}
+
+func clamp01(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x > 1:
+		return 1
+	default:
+		return x
+	}
+}
+
+func argmax(fit []float64) int {
+	best := 0
+	for i, f := range fit {
+		if f > fit[best] {
+			best = i
+		}
+	}
+	return best
+}
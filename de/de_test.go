@@ -0,0 +1,42 @@
+package de
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// sphereFitness scores vec by closeness to the all-0.5 vector, scaled so
+// that a perfect match scores 1.0 and the score degrades smoothly, giving
+// DE a gradient to climb.
+func sphereFitness(vec []float64) float64 {
+	var sum float64
+	for _, x := range vec {
+		d := x - 0.5
+		sum += d * d
+	}
+	maxSum := float64(len(vec)) * 0.25
+	return 1.0 - sum/maxSum
+}
+
+func TestDEConverges(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	solver := New(10, 40, 200, 0.8, 0.9, Rand1Bin, sphereFitness, rng)
+	_, fitness, _ := solver.Run()
+	if fitness < 0.99 {
+		t.Errorf("fitness = %v, want >= 0.99", fitness)
+	}
+}
+
+func TestNewPanicsOnTooSmallNP(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for np := uint(0); np < minNP; np++ {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New(np=%d): expected a panic, got none", np)
+				}
+			}()
+			New(4, np, 10, 0.8, 0.9, Rand1Bin, sphereFitness, rng)
+		}()
+	}
+}
@@ -0,0 +1,125 @@
+package ga
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// PopulationInitializer lets an Initializer seed an entire population at
+// once, rather than one genome at a time, so it can take relationships
+// between genomes (e.g. diversity) into account. If the Initializer
+// configured on a GA also implements this, InitPopulation uses it;
+// otherwise InitPopulation falls back to calling New repeatedly.
+type PopulationInitializer interface {
+	InitPopulation(popSize uint, rng *rand.Rand) []Genome
+}
+
+// DistanceFunc returns a non-negative distance between two genomes of
+// the type produced by Candidate.
+type DistanceFunc func(a, b Genome) float64
+
+// DiverseInitializer seeds a population with a k-means++-style
+// diversity pass: candidates are drawn from an oversampled pool, the
+// first seed is picked uniformly at random, and every subsequent seed is
+// picked with probability proportional to its squared distance to the
+// nearest seed chosen so far. This spreads the initial population out
+// instead of the near-duplicate seeds plain uniform sampling tends to
+// produce on larger problems.
+type DiverseInitializer struct {
+	// Candidate draws one raw candidate genome from the problem's random
+	// distribution; DiverseInitializer filters its output for diversity.
+	Candidate Initializer
+	// Distance measures how different two candidate genomes are.
+	Distance DistanceFunc
+	// Oversample controls the candidate pool size, as a multiple of the
+	// requested population size. Defaults to 10 if zero.
+	Oversample uint
+}
+
+// New implements Initializer by drawing a single candidate directly; the
+// diversity pass only applies when seeding a whole population via
+// InitPopulation.
+func (d DiverseInitializer) New(rng *rand.Rand) Genome {
+	return d.Candidate.New(rng)
+}
+
+// InitPopulation implements PopulationInitializer.
+func (d DiverseInitializer) InitPopulation(popSize uint, rng *rand.Rand) []Genome {
+	oversample := d.Oversample
+	if oversample == 0 {
+		oversample = 10
+	}
+	poolSize := int(popSize * oversample)
+	if poolSize < int(popSize) {
+		poolSize = int(popSize)
+	}
+
+	pool := make([]Genome, poolSize)
+	for i := range pool {
+		pool[i] = d.Candidate.New(rng)
+	}
+
+	chosen := make([]bool, poolSize)
+	first := rng.Intn(poolSize)
+	chosen[first] = true
+	seeds := make([]Genome, 1, popSize)
+	seeds[0] = pool[first]
+
+	d2 := make([]float64, poolSize)
+	for i := range pool {
+		if chosen[i] {
+			continue
+		}
+		dist := d.Distance(pool[i], pool[first])
+		d2[i] = dist * dist
+	}
+
+	cumsum := make([]float64, poolSize)
+	for uint(len(seeds)) < popSize {
+		var running float64
+		for i, v := range d2 {
+			if chosen[i] {
+				v = 0
+			}
+			running += v
+			cumsum[i] = running
+		}
+
+		var pick int
+		if running <= 0 {
+			// Every remaining candidate coincides with an already-chosen
+			// seed; take the first one left.
+			pick = -1
+			for i, c := range chosen {
+				if !c {
+					pick = i
+					break
+				}
+			}
+		} else {
+			target := rng.Float64() * cumsum[poolSize-1]
+			pick = sort.SearchFloat64s(cumsum, target)
+			for pick < poolSize && chosen[pick] {
+				pick++
+			}
+			if pick >= poolSize {
+				pick = poolSize - 1
+			}
+		}
+
+		chosen[pick] = true
+		seeds = append(seeds, pool[pick])
+
+		for i := range pool {
+			if chosen[i] {
+				continue
+			}
+			dist := d.Distance(pool[i], pool[pick])
+			if nd := dist * dist; nd < d2[i] {
+				d2[i] = nd
+			}
+		}
+	}
+
+	return seeds
+}
@@ -0,0 +1,38 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/xyproto/nqueens/ga"
+	"github.com/xyproto/nqueens/nqueens"
+)
+
+// TestDiverseInitializerInitPopulation checks that DiverseInitializer
+// returns exactly popSize genomes and that they are not all identical,
+// which plain uniform sampling on a large board would risk.
+func TestDiverseInitializerInitPopulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := ga.DiverseInitializer{
+		Candidate: nqueens.PermRandomInitializer{NumQueens: 8},
+		Distance:  nqueens.PermHammingDistance,
+	}
+
+	const popSize = 20
+	pop := d.InitPopulation(popSize, rng)
+	if len(pop) != popSize {
+		t.Fatalf("InitPopulation: got %d genomes, want %d", len(pop), popSize)
+	}
+
+	first := pop[0].(nqueens.PermSolution)
+	allSame := true
+	for _, g := range pop[1:] {
+		if nqueens.PermHammingDistance(first, g.(nqueens.PermSolution)) != 0 {
+			allSame = false
+			break
+		}
+	}
+	if allSame {
+		t.Error("InitPopulation: every genome is identical, expected diversity-seeded spread")
+	}
+}
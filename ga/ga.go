@@ -0,0 +1,210 @@
+// Package ga provides a small, pluggable genetic algorithm engine.
+//
+// The engine itself knows nothing about the problem being solved. It is
+// wired up with a Genome implementation plus Initializer, Selector,
+// Crossover and Mutator strategies, and drives the generational loop on
+// their behalf. This lets a single engine be reused across unrelated
+// problems (and, within one problem, across competing operator
+// configurations) instead of hardcoding the loop once per use case.
+package ga
+
+import (
+	"math/rand"
+)
+
+// Genome is one candidate solution. Fitness must return a value in [0, 1],
+// where 1.0 means the genome solves the problem. Clone must return an
+// independent copy that shares no mutable state with the receiver, so
+// that callers (notably Archipelago, which copies genomes between
+// islands running in their own goroutines) never end up with two
+// genomes aliasing the same backing storage.
+type Genome interface {
+	Fitness() float64
+	Clone() Genome
+}
+
+// Initializer creates a single, freshly generated Genome. It is used both
+// to seed the initial population and to re-seed individuals that the
+// engine decides to replace with randomness during a run.
+type Initializer interface {
+	New(rng *rand.Rand) Genome
+}
+
+// Selector picks one individual out of the population, returning its
+// index. Implementations are free to use the fitnesses however they like
+// (tournament, roulette wheel, ...).
+type Selector interface {
+	Select(pop []Genome, fitnesses []float64, rng *rand.Rand) int
+}
+
+// Crossover combines two parent genomes into a single child genome.
+type Crossover interface {
+	Cross(a, b Genome, rng *rand.Rand) Genome
+}
+
+// Mutator returns a mutated version of g. Implementations may mutate g in
+// place and return it, or return a fresh value; callers must use the
+// returned Genome rather than assume g was touched.
+type Mutator interface {
+	Mutate(g Genome, rng *rand.Rand) Genome
+}
+
+// GA holds the configuration for one run of the genetic algorithm engine.
+// The zero value is not usable; construct one with New.
+type GA struct {
+	PopSize        uint
+	MaxGenerations uint
+
+	Initializer Initializer
+	Selector    Selector
+	Crossover   Crossover
+	Mutator     Mutator
+
+	Rng *rand.Rand
+}
+
+// New returns a GA with the given population size, generation budget and
+// operators, ready to Run.
+func New(popSize, maxGenerations uint, initializer Initializer, selector Selector, crossover Crossover, mutator Mutator, rng *rand.Rand) *GA {
+	return &GA{
+		PopSize:        popSize,
+		MaxGenerations: maxGenerations,
+		Initializer:    initializer,
+		Selector:       selector,
+		Crossover:      crossover,
+		Mutator:        mutator,
+		Rng:            rng,
+	}
+}
+
+// InitPopulation seeds a fresh population of PopSize genomes via the
+// Initializer. Run calls this itself; it is exported so that callers
+// driving the generational loop externally (e.g. Archipelago, for the
+// island model) can seed each island's starting population.
+func (g *GA) InitPopulation() []Genome {
+	if pi, ok := g.Initializer.(PopulationInitializer); ok {
+		return pi.InitPopulation(g.PopSize, g.Rng)
+	}
+	pop := make([]Genome, g.PopSize)
+	for i := range pop {
+		pop[i] = g.Initializer.New(g.Rng)
+	}
+	return pop
+}
+
+// StepGeneration advances pop by exactly one generation and returns the
+// resulting population along with the index and fitness of its best
+// genome. Run calls this in a loop; Archipelago calls it once per island
+// per generation so that islands can be advanced independently between
+// migration ticks.
+//
+// The replacement policy (individuals far enough below the population
+// average get reseeded via the Initializer) and the mutation/crossover
+// rate schedule (tightened once the best individual pulls ahead of the
+// average, and boosted when the population has stalled on a plateau) are
+// fixed parts of the engine, mirroring what used to be an ad-hoc loop
+// wired directly into the N-queens solver.
+func (g *GA) StepGeneration(pop []Genome) ([]Genome, int, float64) {
+	fitnesses := make([]float64, len(pop))
+	var total float64
+	for i, ind := range pop {
+		fitnesses[i] = ind.Fitness()
+		total += fitnesses[i]
+	}
+	average := total / float64(len(pop))
+
+	var bestFitness, nextBestFitness float64
+	bestIndex := 0
+	for i, f := range fitnesses {
+		if f >= bestFitness {
+			nextBestFitness = bestFitness
+			bestFitness = f
+			bestIndex = i
+		}
+	}
+
+	if bestFitness == 1.0 {
+		return pop, bestIndex, bestFitness
+	}
+
+	mutationRate, crossoverRate := 0.4, 0.4
+	if bestFitness > average {
+		mutationRate, crossoverRate = 0.15, 0.07
+	}
+	if bestFitness == nextBestFitness {
+		// The population has stalled on a plateau: push harder.
+		mutationRate *= 3.0
+	}
+	newPopRate := 0.2
+	if average > 0.9 {
+		newPopRate = 0.4
+	}
+
+	for i := range pop {
+		fitness := fitnesses[i]
+		switch {
+		case average > 0.7 && fitness < 0.5:
+			pop[i] = g.Initializer.New(g.Rng)
+		case average > 0.8 && fitness < 0.6:
+			pop[i] = g.Initializer.New(g.Rng)
+		case average > 0.9 && fitness < 0.7:
+			pop[i] = g.Initializer.New(g.Rng)
+		case fitness < (average * 0.3):
+			// 50% chance of being replaced with randomness
+			if g.Rng.Float64() <= 0.5 {
+				pop[i] = g.Initializer.New(g.Rng)
+			}
+		}
+
+		// An advantage for the best ones: usually leave them alone.
+		if fitness > (bestFitness*0.9) && g.Rng.Float64() <= 0.9 {
+			continue
+		}
+		if g.Rng.Float64() <= mutationRate {
+			victim := g.Rng.Intn(len(pop))
+			pop[victim] = g.Mutator.Mutate(pop[victim], g.Rng)
+		}
+		if g.Rng.Float64() <= crossoverRate {
+			a := g.Selector.Select(pop, fitnesses, g.Rng)
+			b := g.Selector.Select(pop, fitnesses, g.Rng)
+			pop[i] = g.Crossover.Cross(pop[a], pop[b], g.Rng)
+		}
+		if g.Rng.Float64() <= newPopRate {
+			pop[i] = g.Initializer.New(g.Rng)
+		}
+	}
+
+	// The loop above may have replaced or mutated the genome found best
+	// before it, so report the best of the population as it now stands.
+	bestFitness, bestIndex = 0, 0
+	for i, ind := range pop {
+		if f := ind.Fitness(); f >= bestFitness {
+			bestFitness = f
+			bestIndex = i
+		}
+	}
+
+	return pop, bestIndex, bestFitness
+}
+
+// Run drives the generational loop until a genome reaches fitness 1.0 or
+// MaxGenerations is exhausted, and returns the best genome of the final
+// population along with the generation the run stopped at
+// (MaxGenerations if no genome reached fitness 1.0). A genome that was
+// better in an earlier generation but has since been replaced is not
+// returned.
+func (g *GA) Run() (Genome, uint) {
+	pop := g.InitPopulation()
+
+	bestIndex := 0
+	var generation uint
+	for generation = 0; generation < g.MaxGenerations; generation++ {
+		var bestFitness float64
+		pop, bestIndex, bestFitness = g.StepGeneration(pop)
+		if bestFitness == 1.0 {
+			break
+		}
+	}
+
+	return pop[bestIndex], generation
+}
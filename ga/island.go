@@ -0,0 +1,186 @@
+package ga
+
+import (
+	"sort"
+	"sync"
+)
+
+// Archipelago runs several GA populations ("islands") side by side,
+// periodically migrating individuals between them. Running isolated
+// subpopulations and occasionally mixing them is a standard cure for the
+// premature convergence a single population is prone to, without
+// resorting to the ad-hoc mutation-rate tripling StepGeneration falls
+// back on when the population stalls on a plateau.
+type Archipelago struct {
+	Islands []*GA
+
+	// MigrationInterval is how many generations pass between migrations.
+	MigrationInterval uint
+	// MigrationSize is how many individuals move on each migration tick.
+	MigrationSize uint
+	// RandomTopology sends each island's migrants to a random other
+	// island instead of the next one in a ring.
+	RandomTopology bool
+}
+
+// NewArchipelago returns an Archipelago driving the given islands.
+func NewArchipelago(islands []*GA, migrationInterval, migrationSize uint, randomTopology bool) *Archipelago {
+	return &Archipelago{
+		Islands:           islands,
+		MigrationInterval: migrationInterval,
+		MigrationSize:     migrationSize,
+		RandomTopology:    randomTopology,
+	}
+}
+
+// IslandStats summarizes one island's state at the point the run ended.
+type IslandStats struct {
+	BestFitness float64
+	Generation  uint
+}
+
+// ArchipelagoStats summarizes a whole Solve call.
+type ArchipelagoStats struct {
+	PerIsland   []IslandStats
+	BestIsland  int
+	BestFitness float64
+}
+
+// Solve advances every island one generation at a time, in parallel, for
+// up to maxGenerations, migrating individuals between islands every
+// MigrationInterval generations. It returns the best genome in any
+// island's final population, plus per-island and aggregate statistics.
+func (a *Archipelago) Solve(maxGenerations uint) (Genome, ArchipelagoStats) {
+	k := len(a.Islands)
+	pops := make([][]Genome, k)
+	for i, isl := range a.Islands {
+		pops[i] = isl.InitPopulation()
+	}
+
+	bestIndex := make([]int, k)
+	bestFitness := make([]float64, k)
+	generation := make([]uint, k)
+
+	for gen := uint(0); gen < maxGenerations; gen++ {
+		var wg sync.WaitGroup
+		wg.Add(k)
+		for i := 0; i < k; i++ {
+			go func(i int) {
+				defer wg.Done()
+				next, bi, bf := a.Islands[i].StepGeneration(pops[i])
+				pops[i] = next
+				bestIndex[i] = bi
+				bestFitness[i] = bf
+				generation[i] = gen
+			}(i)
+		}
+		wg.Wait()
+
+		solved := false
+		for _, bf := range bestFitness {
+			if bf == 1.0 {
+				solved = true
+				break
+			}
+		}
+		if solved {
+			break
+		}
+
+		// No migration after the last generation, so the recorded best
+		// indices still point at the genomes their fitnesses belong to.
+		if a.MigrationInterval > 0 && gen > 0 && gen+1 < maxGenerations && gen%a.MigrationInterval == 0 {
+			a.migrate(pops)
+		}
+	}
+
+	stats := ArchipelagoStats{PerIsland: make([]IslandStats, k)}
+	for i := range pops {
+		stats.PerIsland[i] = IslandStats{BestFitness: bestFitness[i], Generation: generation[i]}
+		if bestFitness[i] > stats.BestFitness {
+			stats.BestFitness = bestFitness[i]
+			stats.BestIsland = i
+		}
+	}
+	return pops[stats.BestIsland][bestIndex[stats.BestIsland]], stats
+}
+
+// migrate copies each island's top MigrationSize individuals into its
+// destination island (the next island in a ring, or a random other
+// island under RandomTopology), replacing that island's worst
+// MigrationSize individuals.
+//
+// Every island's migrants are read out before any island's population is
+// mutated, so which islands act as each other's source and destination
+// in the same tick does not matter.
+func (a *Archipelago) migrate(pops [][]Genome) {
+	k := len(pops)
+	m := int(a.MigrationSize)
+	if m <= 0 || k < 2 {
+		return
+	}
+
+	order := make([][]int, k)
+	for i, pop := range pops {
+		idx := make([]int, len(pop))
+		fit := make([]float64, len(pop))
+		for j, ind := range pop {
+			idx[j] = j
+			fit[j] = ind.Fitness()
+		}
+		sort.Slice(idx, func(x, y int) bool { return fit[idx[x]] > fit[idx[y]] })
+		order[i] = idx
+	}
+
+	destOf := func(i int) int {
+		if !a.RandomTopology {
+			return (i + 1) % k
+		}
+		d := a.Islands[i].Rng.Intn(k - 1)
+		if d >= i {
+			d++
+		}
+		return d
+	}
+
+	// How many individuals actually move from i to its destination is
+	// capped by MigrationSize but also by both islands' population
+	// sizes, which may be smaller than MigrationSize (e.g. a large
+	// island count dividing popSize down to single digits).
+	dest := make([]int, k)
+	count := make([]int, k)
+	migrants := make([][]Genome, k)
+	for i, pop := range pops {
+		d := destOf(i)
+		dest[i] = d
+		n := m
+		if len(pop) < n {
+			n = len(pop)
+		}
+		if len(pops[d]) < n {
+			n = len(pops[d])
+		}
+		count[i] = n
+
+		best := make([]Genome, n)
+		for j := 0; j < n; j++ {
+			// Clone so the destination island's copy never shares
+			// backing storage (e.g. Genes/Rows slices) with the source
+			// island's individual: both islands mutate concurrently.
+			best[j] = pop[order[i][j]].Clone()
+		}
+		migrants[i] = best
+	}
+
+	for i := range pops {
+		d := dest[i]
+		n := count[i]
+		if n == 0 {
+			continue
+		}
+		worst := order[d][len(order[d])-n:]
+		for j, slot := range worst {
+			pops[d][slot] = migrants[i][j]
+		}
+	}
+}
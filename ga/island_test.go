@@ -0,0 +1,49 @@
+package ga_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/xyproto/nqueens/ga"
+	"github.com/xyproto/nqueens/nqueens"
+)
+
+// newTestArchipelago builds a small island model for the permutation
+// N-queens encoding, small enough to run quickly under -race while still
+// exercising migration (population per island is smaller than
+// MigrationSize, which is the scenario chunk0-4's clamp fix targets).
+func newTestArchipelago(numQueens, numIslands, islandPopSize uint, seed int64) *ga.Archipelago {
+	rng := rand.New(rand.NewSource(seed))
+	engines := make([]*ga.GA, numIslands)
+	for i := range engines {
+		islandRng := rand.New(rand.NewSource(rng.Int63()))
+		engines[i] = ga.New(
+			islandPopSize,
+			200,
+			nqueens.PermRandomInitializer{NumQueens: numQueens},
+			ga.TournamentSelector{K: 2},
+			nqueens.OrderCrossover{},
+			nqueens.PermSwapMutator{},
+			islandRng,
+		)
+	}
+	return ga.NewArchipelago(engines, 5, 5, false)
+}
+
+// TestArchipelagoSolve exercises the concurrent island loop end to end,
+// including migration ticks where MigrationSize (5) exceeds each
+// island's population (4): run this test with -race to confirm islands
+// never share mutable genome state.
+func TestArchipelagoSolve(t *testing.T) {
+	a := newTestArchipelago(8, 4, 4, 1)
+	best, stats := a.Solve(500)
+	if best.Fitness() != 1.0 {
+		t.Errorf("Archipelago.Solve: best fitness = %v, want 1.0", best.Fitness())
+	}
+	if stats.BestFitness != best.Fitness() {
+		t.Errorf("stats.BestFitness = %v, want %v", stats.BestFitness, best.Fitness())
+	}
+	if stats.BestIsland < 0 || stats.BestIsland >= len(stats.PerIsland) {
+		t.Errorf("stats.BestIsland = %d, out of range for %d islands", stats.BestIsland, len(stats.PerIsland))
+	}
+}
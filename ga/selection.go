@@ -0,0 +1,49 @@
+package ga
+
+import "math/rand"
+
+// TournamentSelector selects the fittest of K individuals drawn uniformly
+// at random from the population.
+type TournamentSelector struct {
+	K int
+}
+
+// Select implements Selector.
+func (t TournamentSelector) Select(pop []Genome, fitnesses []float64, rng *rand.Rand) int {
+	k := t.K
+	if k < 1 {
+		k = 1
+	}
+	best := rng.Intn(len(pop))
+	for i := 1; i < k; i++ {
+		candidate := rng.Intn(len(pop))
+		if fitnesses[candidate] > fitnesses[best] {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// RouletteWheelSelector selects individuals with probability proportional
+// to their fitness (fitness-proportionate selection).
+type RouletteWheelSelector struct{}
+
+// Select implements Selector.
+func (RouletteWheelSelector) Select(pop []Genome, fitnesses []float64, rng *rand.Rand) int {
+	var total float64
+	for _, f := range fitnesses {
+		total += f
+	}
+	if total <= 0 {
+		return rng.Intn(len(pop))
+	}
+	target := rng.Float64() * total
+	var cumulative float64
+	for i, f := range fitnesses {
+		cumulative += f
+		if cumulative >= target {
+			return i
+		}
+	}
+	return len(fitnesses) - 1
+}
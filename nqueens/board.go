@@ -0,0 +1,156 @@
+// Package nqueens models the N-queens board and solution representations,
+// and wires them into the ga engine via the ga.Genome, ga.Initializer,
+// ga.Crossover and ga.Mutator interfaces.
+package nqueens
+
+import "errors"
+
+// PosType is the state of a single board cell.
+type PosType byte
+
+const (
+	// Free marks an empty, unthreatened cell.
+	Free PosType = iota
+	// Queen marks a cell occupied by a queen.
+	Queen
+	// Covered marks a cell threatened by a queen.
+	Covered
+)
+
+// BoardPosIndex indexes all positions on the board, from 0 up to N*N.
+type BoardPosIndex uint
+
+// FreePosIndex indexes the free positions on the board, from 0 up to
+// however many are still free.
+type FreePosIndex uint
+
+// Position is an (x, y) coordinate on the board.
+type Position struct {
+	X uint
+	Y uint
+}
+
+// Board is a width*width grid of cells.
+type Board struct {
+	width uint
+	data  []PosType
+}
+
+// NewBoard creates an empty board of the given width.
+func NewBoard(w uint) *Board {
+	return &Board{
+		width: w,
+		data:  make([]PosType, w*w),
+	}
+}
+
+// pos2xy converts a linear board index into an (x, y) coordinate for a
+// board of the given width. It returns false if pos is out of range.
+func pos2xy(width uint, pos BoardPosIndex) (Position, bool) {
+	if pos >= BoardPosIndex(width*width) {
+		return Position{}, false
+	}
+	return Position{X: uint(pos) % width, Y: uint(pos) / width}, true
+}
+
+// diagonalSteps are the four directions a queen threatens along a
+// diagonal: up-left, up-right, down-left, down-right.
+var diagonalSteps = [4][2]int{
+	{-1, -1}, {1, -1}, {-1, 1}, {1, 1},
+}
+
+// Place puts a queen at the targetpos'th free position on the board,
+// marking its row, column and both full diagonals as covered. It
+// returns the board position the queen ended up at.
+func (b *Board) Place(targetpos FreePosIndex) (BoardPosIndex, error) {
+	var freepos FreePosIndex
+	var usepos BoardPosIndex
+
+	width := b.width
+	height := width
+
+	for usepos = 0; usepos < BoardPosIndex(width*width); usepos++ {
+		if (targetpos == freepos) && (b.data[usepos] == Free) {
+			o, ok := pos2xy(width, usepos)
+			if !ok {
+				return 0, errors.New("invalid board position")
+			}
+
+			for x := uint(0); x < width; x++ {
+				b.data[o.Y*width+x] = Covered
+			}
+			for y := uint(0); y < height; y++ {
+				b.data[y*width+o.X] = Covered
+			}
+			for _, step := range diagonalSteps {
+				x, y := int(o.X)+step[0], int(o.Y)+step[1]
+				for x >= 0 && x < int(width) && y >= 0 && y < int(height) {
+					b.data[uint(y)*width+uint(x)] = Covered
+					x += step[0]
+					y += step[1]
+				}
+			}
+
+			// Mark the queen
+			b.data[usepos] = Queen
+			return usepos, nil
+		}
+		if b.data[usepos] == Free {
+			freepos++
+		}
+	}
+	return 0, errors.New("no available position")
+}
+
+// Conflicts returns the number of distinct queen pairs on the board that
+// threaten each other along a shared row, column or diagonal.
+func (b *Board) Conflicts() int {
+	var queens []Position
+	width := b.width
+	for usepos, t := range b.data {
+		if t != Queen {
+			continue
+		}
+		p, _ := pos2xy(width, BoardPosIndex(usepos))
+		queens = append(queens, p)
+	}
+
+	var conflicts int
+	for i := 0; i < len(queens); i++ {
+		for j := i + 1; j < len(queens); j++ {
+			a, c := queens[i], queens[j]
+			if a.X == c.X || a.Y == c.Y {
+				conflicts++
+				continue
+			}
+			dx := int(a.X) - int(c.X)
+			dy := int(a.Y) - int(c.Y)
+			if dx == dy || dx == -dy {
+				conflicts++
+			}
+		}
+	}
+	return conflicts
+}
+
+// String renders the board as a grid of 'q' (queen), '.' (covered) and
+// ' ' (free) characters, one row per line.
+func (b *Board) String() string {
+	var s string
+	width := b.width
+	height := width
+	for y := uint(0); y < height; y++ {
+		for x := uint(0); x < width; x++ {
+			switch b.data[y*width+x] {
+			case Free:
+				s += " "
+			case Queen:
+				s += "q"
+			case Covered:
+				s += "."
+			}
+		}
+		s += "\n"
+	}
+	return s + "\n"
+}
@@ -0,0 +1,94 @@
+package nqueens
+
+import "testing"
+
+func TestSolveExact(t *testing.T) {
+	for _, n := range []uint{1, 4, 5, 8, 12} {
+		sol, ok := SolveExact(n)
+		if !ok {
+			t.Errorf("SolveExact(%d): expected a solution, got none", n)
+			continue
+		}
+		if len(sol.Rows) != int(n) {
+			t.Errorf("SolveExact(%d): got %d rows, want %d", n, len(sol.Rows), n)
+			continue
+		}
+		if fitness := sol.Fitness(); fitness != 1.0 {
+			t.Errorf("SolveExact(%d): fitness = %v, want 1.0", n, fitness)
+		}
+	}
+}
+
+func TestSolveExactNoSolution(t *testing.T) {
+	for _, n := range []uint{2, 3} {
+		if _, ok := SolveExact(n); ok {
+			t.Errorf("SolveExact(%d): expected no solution to exist", n)
+		}
+	}
+}
+
+// freePosIndexFor returns the FreePosIndex that Board.Place would need to
+// land a queen at board coordinate (x, y), given b's current occupancy.
+// It mirrors Place's own free-cell counting so callers can drive Place
+// column by column from a target (x, y) layout such as SolveExact's.
+func freePosIndexFor(b *Board, width, x, y uint) FreePosIndex {
+	target := y*width + x
+	var count FreePosIndex
+	for i := uint(0); i < target; i++ {
+		if b.data[i] == Free {
+			count++
+		}
+	}
+	return count
+}
+
+// TestPlaceCoversFullDiagonals is a regression test for the diagonal
+// marking bug Board.Place used to have, where only one diagonal cell per
+// row was ever marked instead of the full two diagonals through the
+// queen. It drives Place itself, one queen per column, to lay out the
+// exact solver's solution, checks after each placement that no cell on
+// the new queen's diagonals is left Free, then checks that the board
+// Place actually produced has no conflicts.
+func TestPlaceCoversFullDiagonals(t *testing.T) {
+	const n = 8
+	sol, ok := SolveExact(n)
+	if !ok {
+		t.Fatal("SolveExact(8): expected a solution")
+	}
+	board := NewBoard(n)
+	for col, row := range sol.Rows {
+		fp := freePosIndexFor(board, n, uint(col), row)
+		if _, err := board.Place(fp); err != nil {
+			t.Fatalf("Place(col=%d, row=%d): %v", col, row, err)
+		}
+		for _, step := range diagonalSteps {
+			x, y := col+step[0], int(row)+step[1]
+			for x >= 0 && x < n && y >= 0 && y < n {
+				if board.data[y*n+x] == Free {
+					t.Errorf("after Place(col=%d, row=%d): diagonal cell (%d, %d) is still Free", col, row, x, y)
+				}
+				x += step[0]
+				y += step[1]
+			}
+		}
+	}
+	if conflicts := board.Conflicts(); conflicts != 0 {
+		t.Errorf("Conflicts() = %d, want 0 for a board Place laid out from a valid exact solution", conflicts)
+	}
+}
+
+// TestPlaceMarksBothDiagonals places a single queen in the middle of an
+// otherwise empty board and checks that all four diagonal neighbors are
+// marked Covered, not just the one the old buggy Place left Free.
+func TestPlaceMarksBothDiagonals(t *testing.T) {
+	const width = 5
+	board := NewBoard(width)
+	if _, err := board.Place(freePosIndexFor(board, width, 2, 2)); err != nil {
+		t.Fatalf("Place: %v", err)
+	}
+	for _, d := range []struct{ x, y uint }{{1, 1}, {3, 3}, {1, 3}, {3, 1}} {
+		if got := board.data[d.y*width+d.x]; got != Covered {
+			t.Errorf("board.data[%d,%d] = %v, want Covered", d.x, d.y, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package nqueens
+
+import (
+	"math/rand"
+
+	"github.com/xyproto/nqueens/ga"
+)
+
+// RandomInitializer implements ga.Initializer by drawing a fresh,
+// uniformly random Solution.
+type RandomInitializer struct {
+	NumQueens uint
+	BoardSize uint
+}
+
+// New implements ga.Initializer.
+func (r RandomInitializer) New(rng *rand.Rand) ga.Genome {
+	return NewRandomSolution(r.NumQueens, r.BoardSize, rng)
+}
+
+// SinglePointCrossover implements ga.Crossover by splicing parent a's
+// genes up to a random cut point with parent b's genes from that point
+// on.
+type SinglePointCrossover struct{}
+
+// Cross implements ga.Crossover.
+func (SinglePointCrossover) Cross(a, b ga.Genome, rng *rand.Rand) ga.Genome {
+	pa := a.(Solution)
+	pb := b.(Solution)
+	point := uint(rng.Intn(int(pa.NumQueens)))
+	child := NewSolution(pa.NumQueens, pa.BoardSize)
+	copy(child.Genes[:point], pa.Genes[:point])
+	copy(child.Genes[point:], pb.Genes[point:])
+	return child
+}
+
+// TwoPointCrossover implements ga.Crossover by picking two cut points
+// i <= j and taking the middle segment [i:j) from parent b, with
+// everything outside it from parent a. When i == j the segment is empty
+// and the child is a copy of a.
+type TwoPointCrossover struct{}
+
+// Cross implements ga.Crossover.
+func (TwoPointCrossover) Cross(a, b ga.Genome, rng *rand.Rand) ga.Genome {
+	pa := a.(Solution)
+	pb := b.(Solution)
+	n := int(pa.NumQueens)
+	i := rng.Intn(n)
+	j := rng.Intn(n)
+	if i > j {
+		i, j = j, i
+	}
+	child := NewSolution(pa.NumQueens, pa.BoardSize)
+	copy(child.Genes, pa.Genes)
+	copy(child.Genes[i:j], pb.Genes[i:j])
+	return child
+}
+
+// UniformCrossover implements ga.Crossover by taking each gene from
+// parent a or parent b with equal probability, independently per gene.
+type UniformCrossover struct{}
+
+// Cross implements ga.Crossover.
+func (UniformCrossover) Cross(a, b ga.Genome, rng *rand.Rand) ga.Genome {
+	pa := a.(Solution)
+	pb := b.(Solution)
+	child := NewSolution(pa.NumQueens, pa.BoardSize)
+	for i := range child.Genes {
+		if rng.Float64() < 0.5 {
+			child.Genes[i] = pa.Genes[i]
+		} else {
+			child.Genes[i] = pb.Genes[i]
+		}
+	}
+	return child
+}
+
+// HammingDistance implements ga.DistanceFunc for the free-position
+// encoding, counting the genes at which two solutions differ.
+func HammingDistance(a, b ga.Genome) float64 {
+	sa := a.(Solution)
+	sb := b.(Solution)
+	var d float64
+	for i := range sa.Genes {
+		if sa.Genes[i] != sb.Genes[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// ResetMutator implements ga.Mutator by picking one gene at random and
+// replacing it with a fresh random free-position index.
+type ResetMutator struct{}
+
+// Mutate implements ga.Mutator.
+func (ResetMutator) Mutate(g ga.Genome, rng *rand.Rand) ga.Genome {
+	sol := g.(Solution)
+	randpos := rng.Intn(int(sol.NumQueens))
+	sol.Genes[randpos] = FreePosIndex(rng.Intn(int(sol.BoardSize * sol.BoardSize)))
+	return sol
+}
@@ -0,0 +1,96 @@
+package nqueens
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSinglePointCrossover(t *testing.T) {
+	a := Solution{NumQueens: 6, BoardSize: 6, Genes: []FreePosIndex{10, 11, 12, 13, 14, 15}}
+	b := Solution{NumQueens: 6, BoardSize: 6, Genes: []FreePosIndex{20, 21, 22, 23, 24, 25}}
+
+	rng := rand.New(rand.NewSource(7))
+	child := SinglePointCrossover{}.Cross(a, b, rng).(Solution)
+
+	// Seed 7 draws cut point 2: genes before it come from a, from it on
+	// come from b.
+	want := []FreePosIndex{10, 11, 22, 23, 24, 25}
+	if !reflect.DeepEqual(child.Genes, want) {
+		t.Errorf("Cross() = %v, want %v", child.Genes, want)
+	}
+}
+
+func TestTwoPointCrossover(t *testing.T) {
+	a := Solution{NumQueens: 6, BoardSize: 6, Genes: []FreePosIndex{10, 11, 12, 13, 14, 15}}
+	b := Solution{NumQueens: 6, BoardSize: 6, Genes: []FreePosIndex{20, 21, 22, 23, 24, 25}}
+
+	rng := rand.New(rand.NewSource(1))
+	child := TwoPointCrossover{}.Cross(a, b, rng).(Solution)
+
+	// Seed 1 draws cut points 3 and 5: the middle segment [3:5) comes
+	// from b, everything else from a.
+	want := []FreePosIndex{10, 11, 12, 23, 24, 15}
+	if !reflect.DeepEqual(child.Genes, want) {
+		t.Errorf("Cross() = %v, want %v", child.Genes, want)
+	}
+}
+
+func TestUniformCrossover(t *testing.T) {
+	a := Solution{NumQueens: 40, BoardSize: 40, Genes: make([]FreePosIndex, 40)}
+	b := Solution{NumQueens: 40, BoardSize: 40, Genes: make([]FreePosIndex, 40)}
+	for i := range a.Genes {
+		a.Genes[i] = FreePosIndex(i)
+		b.Genes[i] = FreePosIndex(1000 + i)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	child := UniformCrossover{}.Cross(a, b, rng).(Solution)
+
+	var fromA, fromB int
+	for i, g := range child.Genes {
+		switch g {
+		case a.Genes[i]:
+			fromA++
+		case b.Genes[i]:
+			fromB++
+		default:
+			t.Fatalf("Cross(): gene %d = %v, want either %v or %v", i, g, a.Genes[i], b.Genes[i])
+		}
+	}
+	if fromA == 0 || fromB == 0 {
+		t.Errorf("Cross(): got %d genes from a and %d from b, want a mix of both over 40 genes", fromA, fromB)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	a := Solution{Genes: []FreePosIndex{1, 2, 3, 4}}
+	b := Solution{Genes: []FreePosIndex{1, 0, 3, 0}}
+	if d := HammingDistance(a, b); d != 2 {
+		t.Errorf("HammingDistance() = %v, want 2", d)
+	}
+	if d := HammingDistance(a, a); d != 0 {
+		t.Errorf("HammingDistance(a, a) = %v, want 0", d)
+	}
+}
+
+func TestResetMutator(t *testing.T) {
+	sol := Solution{NumQueens: 4, BoardSize: 4, Genes: []FreePosIndex{1, 1, 1, 1}}
+	// Mutate may change sol.Genes in place, so compare against a copy.
+	orig := append([]FreePosIndex(nil), sol.Genes...)
+	rng := rand.New(rand.NewSource(1))
+	mutated := ResetMutator{}.Mutate(sol, rng).(Solution)
+
+	var changed int
+	for i, g := range mutated.Genes {
+		if g != orig[i] {
+			changed++
+		}
+		if g >= FreePosIndex(mutated.BoardSize*mutated.BoardSize) {
+			t.Errorf("Mutate(): gene %d = %v, out of free-position range [0, %d)", i, g, mutated.BoardSize*mutated.BoardSize)
+		}
+	}
+	if changed > 1 {
+		t.Errorf("Mutate(): changed %d genes, want at most 1", changed)
+	}
+}
@@ -0,0 +1,214 @@
+package nqueens
+
+import (
+	"math/rand"
+
+	"github.com/xyproto/nqueens/ga"
+)
+
+// PermSolution encodes an N-queens layout as a permutation of row
+// indices: Rows[col] is the row the queen in that column sits on. Since
+// every column holds exactly one queen and every row index appears
+// exactly once, row and column conflicts are structurally impossible;
+// only diagonal conflicts remain to be counted. This also makes
+// crossover non-destructive, unlike the free-position encoding in
+// solution.go, where a child can lose queens both parents placed
+// validly.
+type PermSolution struct {
+	Rows []uint
+}
+
+// NewPermSolution returns a PermSolution with n genes, all zeroed.
+func NewPermSolution(n uint) PermSolution {
+	return PermSolution{Rows: make([]uint, n)}
+}
+
+// NewRandomPermSolution returns a PermSolution whose Rows is a uniformly
+// random permutation of 0..n-1.
+func NewRandomPermSolution(n uint, rng *rand.Rand) PermSolution {
+	perm := rng.Perm(int(n))
+	sol := NewPermSolution(n)
+	for col, row := range perm {
+		sol.Rows[col] = uint(row)
+	}
+	return sol
+}
+
+// Clone implements ga.Genome, returning an independent copy of sol whose
+// Rows slice shares no backing array with the receiver's.
+func (sol PermSolution) Clone() ga.Genome {
+	c := NewPermSolution(uint(len(sol.Rows)))
+	copy(c.Rows, sol.Rows)
+	return c
+}
+
+// conflicts counts the number of queen pairs that share a diagonal.
+func (sol PermSolution) conflicts() uint {
+	var n uint
+	for i := 0; i < len(sol.Rows); i++ {
+		for j := i + 1; j < len(sol.Rows); j++ {
+			rowDiff := int(sol.Rows[i]) - int(sol.Rows[j])
+			if rowDiff < 0 {
+				rowDiff = -rowDiff
+			}
+			if rowDiff == j-i {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Fitness implements ga.Genome. It is 1.0 minus the fraction of queen
+// pairs that conflict on a diagonal, so 1.0 means a full solution.
+func (sol PermSolution) Fitness() float64 {
+	n := len(sol.Rows)
+	maxPairs := n * (n - 1) / 2
+	if maxPairs == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(sol.conflicts())/float64(maxPairs)
+}
+
+// String renders the permutation as a board, one 'q' per row.
+func (sol PermSolution) String() string {
+	n := uint(len(sol.Rows))
+	board := NewBoard(n)
+	for col, row := range sol.Rows {
+		board.data[row*n+uint(col)] = Queen
+	}
+	return board.String()
+}
+
+// VectorToPermutation maps a real-valued vector in [0,1]^N (as produced
+// by a solver like de.DE that searches a continuous space) onto the
+// permutation encoding: coordinate i first picks a row via
+// int(vec[i] * N), then any row that collides with an earlier pick is
+// reassigned to the nearest still-unused row so the result is always a
+// valid permutation.
+func VectorToPermutation(vec []float64) PermSolution {
+	n := len(vec)
+	rows := make([]int, n)
+	used := make([]bool, n)
+	for i, x := range vec {
+		row := int(x * float64(n))
+		if row >= n {
+			row = n - 1
+		}
+		if row < 0 {
+			row = 0
+		}
+		rows[i] = row
+	}
+	for i, row := range rows {
+		if used[row] {
+			row = nearestUnused(row, used)
+			rows[i] = row
+		}
+		used[row] = true
+	}
+	sol := NewPermSolution(uint(n))
+	for i, row := range rows {
+		sol.Rows[i] = uint(row)
+	}
+	return sol
+}
+
+// nearestUnused finds the row closest to want that is not yet marked
+// used, preferring the lower row on ties.
+func nearestUnused(want int, used []bool) int {
+	n := len(used)
+	for d := 1; d < n; d++ {
+		if want-d >= 0 && !used[want-d] {
+			return want - d
+		}
+		if want+d < n && !used[want+d] {
+			return want + d
+		}
+	}
+	return want
+}
+
+// PermRandomInitializer implements ga.Initializer for the permutation
+// encoding.
+type PermRandomInitializer struct {
+	NumQueens uint
+}
+
+// New implements ga.Initializer.
+func (p PermRandomInitializer) New(rng *rand.Rand) ga.Genome {
+	return NewRandomPermSolution(p.NumQueens, rng)
+}
+
+// PermHammingDistance implements ga.DistanceFunc for the permutation
+// encoding, counting the columns at which two solutions place their
+// queen on a different row.
+func PermHammingDistance(a, b ga.Genome) float64 {
+	pa := a.(PermSolution)
+	pb := b.(PermSolution)
+	var d float64
+	for i := range pa.Rows {
+		if pa.Rows[i] != pb.Rows[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// OrderCrossover implements ga.Crossover with OX1 order crossover: a
+// random slice of parent a's genes is copied into the child verbatim,
+// and the remaining slots are filled with parent b's genes in b's order,
+// skipping any value the slice from a already contains. The result is
+// always a valid permutation.
+type OrderCrossover struct{}
+
+// Cross implements ga.Crossover.
+func (OrderCrossover) Cross(a, b ga.Genome, rng *rand.Rand) ga.Genome {
+	pa := a.(PermSolution)
+	pb := b.(PermSolution)
+	n := len(pa.Rows)
+
+	i := rng.Intn(n)
+	j := rng.Intn(n)
+	if i > j {
+		i, j = j, i
+	}
+
+	child := NewPermSolution(uint(n))
+	taken := make([]bool, n)
+	for k := i; k < j; k++ {
+		child.Rows[k] = pa.Rows[k]
+		taken[pa.Rows[k]] = true
+	}
+
+	// Starting at j (wrapping), fill the remaining slots (also starting
+	// at j, wrapping, skipping [i,j)) with b's values in b's order.
+	pos := j % n
+	for k := 0; k < n; k++ {
+		row := pb.Rows[(j+k)%n]
+		if taken[row] {
+			continue
+		}
+		for pos >= i && pos < j {
+			pos = (pos + 1) % n
+		}
+		child.Rows[pos] = row
+		taken[row] = true
+		pos = (pos + 1) % n
+	}
+
+	return child
+}
+
+// PermSwapMutator implements ga.Mutator by swapping two randomly chosen
+// genes, which keeps the result a valid permutation.
+type PermSwapMutator struct{}
+
+// Mutate implements ga.Mutator.
+func (PermSwapMutator) Mutate(g ga.Genome, rng *rand.Rand) ga.Genome {
+	sol := g.(PermSolution)
+	i := rng.Intn(len(sol.Rows))
+	j := rng.Intn(len(sol.Rows))
+	sol.Rows[i], sol.Rows[j] = sol.Rows[j], sol.Rows[i]
+	return sol
+}
@@ -0,0 +1,116 @@
+package nqueens
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// isPermutation reports whether rows contains every value in [0, len(rows))
+// exactly once.
+func isPermutation(rows []uint) bool {
+	seen := make([]bool, len(rows))
+	for _, r := range rows {
+		if int(r) >= len(rows) || seen[r] {
+			return false
+		}
+		seen[r] = true
+	}
+	return true
+}
+
+func TestOrderCrossover(t *testing.T) {
+	a := PermSolution{Rows: []uint{0, 1, 2, 3, 4, 5}}
+	b := PermSolution{Rows: []uint{5, 4, 3, 2, 1, 0}}
+
+	rng := rand.New(rand.NewSource(1))
+	child := OrderCrossover{}.Cross(a, b, rng).(PermSolution)
+
+	// Seed 1 draws cut points 3 and 5: a's segment [3:5) (values 3, 4)
+	// is copied verbatim, and the rest is filled from b's order (5, 4,
+	// 3, 2, 1, 0 starting at index 5, wrapping) skipping values already
+	// taken, per the OX1 spec.
+	want := []uint{5, 2, 1, 3, 4, 0}
+	if !reflect.DeepEqual(child.Rows, want) {
+		t.Errorf("Cross() = %v, want %v", child.Rows, want)
+	}
+	if !isPermutation(child.Rows) {
+		t.Errorf("Cross() = %v, not a valid permutation", child.Rows)
+	}
+}
+
+func toRows(perm []int) []uint {
+	rows := make([]uint, len(perm))
+	for i, p := range perm {
+		rows[i] = uint(p)
+	}
+	return rows
+}
+
+func TestOrderCrossoverAlwaysValid(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 200; trial++ {
+		a := PermSolution{Rows: toRows(rng.Perm(8))}
+		b := PermSolution{Rows: toRows(rng.Perm(8))}
+		child := OrderCrossover{}.Cross(a, b, rng).(PermSolution)
+		if !isPermutation(child.Rows) {
+			t.Fatalf("trial %d: Cross() = %v, not a valid permutation", trial, child.Rows)
+		}
+	}
+}
+
+func TestPermSwapMutator(t *testing.T) {
+	sol := PermSolution{Rows: []uint{0, 1, 2, 3, 4}}
+	rng := rand.New(rand.NewSource(1))
+	mutated := PermSwapMutator{}.Mutate(sol, rng).(PermSolution)
+
+	// Seed 1 swaps indices 1 and 2.
+	want := []uint{0, 2, 1, 3, 4}
+	if !reflect.DeepEqual(mutated.Rows, want) {
+		t.Errorf("Mutate() = %v, want %v", mutated.Rows, want)
+	}
+	if !isPermutation(mutated.Rows) {
+		t.Errorf("Mutate() = %v, not a valid permutation", mutated.Rows)
+	}
+}
+
+func TestPermHammingDistance(t *testing.T) {
+	a := PermSolution{Rows: []uint{0, 1, 2, 3}}
+	b := PermSolution{Rows: []uint{0, 2, 1, 3}}
+	if d := PermHammingDistance(a, b); d != 2 {
+		t.Errorf("PermHammingDistance() = %v, want 2", d)
+	}
+	if d := PermHammingDistance(a, a); d != 0 {
+		t.Errorf("PermHammingDistance(a, a) = %v, want 0", d)
+	}
+}
+
+func TestVectorToPermutation(t *testing.T) {
+	tests := []struct {
+		vec  []float64
+		want []uint
+	}{
+		{[]float64{0.0, 0.0, 0.5, 0.9}, []uint{0, 1, 2, 3}},
+		{[]float64{0.5, 0.5, 0.5, 0.5}, []uint{2, 1, 3, 0}},
+		{[]float64{1.0, -0.1, 0.3, 0.7}, []uint{3, 0, 1, 2}},
+	}
+	for _, tt := range tests {
+		got := VectorToPermutation(tt.vec).Rows
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("VectorToPermutation(%v) = %v, want %v", tt.vec, got, tt.want)
+		}
+		if !isPermutation(got) {
+			t.Errorf("VectorToPermutation(%v) = %v, not a valid permutation", tt.vec, got)
+		}
+	}
+}
+
+func TestNearestUnused(t *testing.T) {
+	used := []bool{false, true, false, true, false}
+	if got := nearestUnused(1, used); got != 0 && got != 2 {
+		t.Errorf("nearestUnused(1, ...) = %d, want 0 or 2 (both distance 1)", got)
+	}
+	if got := nearestUnused(3, used); got != 2 && got != 4 {
+		t.Errorf("nearestUnused(3, ...) = %d, want 2 or 4 (both distance 1)", got)
+	}
+}
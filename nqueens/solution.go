@@ -0,0 +1,75 @@
+package nqueens
+
+import (
+	"math/rand"
+
+	"github.com/xyproto/nqueens/ga"
+)
+
+// Solution is a candidate N-queens layout encoded as one free-position
+// index per queen: queen i is placed at the Genes[i]'th free cell
+// remaining on the board once queens 0..i-1 have already been placed.
+// Crossover on this encoding is semantically destructive (a child can
+// drop queens its parents both placed validly), which the permutation
+// encoding in permutation.go avoids.
+type Solution struct {
+	NumQueens uint
+	BoardSize uint
+	Genes     []FreePosIndex
+}
+
+// NewSolution returns a Solution with NumQueens genes, all zeroed.
+func NewSolution(numQueens, boardSize uint) Solution {
+	return Solution{
+		NumQueens: numQueens,
+		BoardSize: boardSize,
+		Genes:     make([]FreePosIndex, numQueens),
+	}
+}
+
+// NewRandomSolution returns a Solution with every gene drawn uniformly at
+// random from the free-position range.
+func NewRandomSolution(numQueens, boardSize uint, rng *rand.Rand) Solution {
+	sol := NewSolution(numQueens, boardSize)
+	maxFreePos := int(boardSize * boardSize)
+	for i := range sol.Genes {
+		sol.Genes[i] = FreePosIndex(rng.Intn(maxFreePos))
+	}
+	return sol
+}
+
+// Clone implements ga.Genome, returning an independent copy of sol whose
+// Genes slice shares no backing array with the receiver's.
+func (sol Solution) Clone() ga.Genome {
+	c := NewSolution(sol.NumQueens, sol.BoardSize)
+	copy(c.Genes, sol.Genes)
+	return c
+}
+
+// generateBoard places every gene onto a fresh board in order, and
+// returns the resulting board along with how many queens were actually
+// placed (placement fails once the board runs out of free positions).
+func (sol Solution) generateBoard() (*Board, uint) {
+	board := NewBoard(sol.BoardSize)
+	var queenCounter uint
+	for _, queenposindex := range sol.Genes {
+		if _, err := board.Place(queenposindex); err == nil {
+			queenCounter++
+		}
+	}
+	return board, queenCounter
+}
+
+// String renders the board that sol produces.
+func (sol Solution) String() string {
+	board, _ := sol.generateBoard()
+	return board.String()
+}
+
+// Fitness implements ga.Genome. It is the fraction of queens that could
+// be placed on the board at all (1.0 means every queen made it onto the
+// board without being blocked by an earlier one).
+func (sol Solution) Fitness() float64 {
+	_, numqueens := sol.generateBoard()
+	return float64(numqueens) / float64(sol.NumQueens)
+}